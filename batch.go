@@ -0,0 +1,148 @@
+package main
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// BatchRequest is the body for POST /excel/batch: one Request per employee,
+// each populated and rendered to xlsx the way /excel does by default.
+// Batch entries are not recorded in the submission store.
+type BatchRequest struct {
+	Employees []Request `json:"employees"`
+}
+
+type batchResult struct {
+	filename string
+	xlsx     []byte
+	err      error
+}
+
+// batchJob pairs a Request with its position in BatchRequest.Employees, so
+// generateBatchEntry has something unique to fall back on when the
+// employee name is empty or sanitizes away to nothing.
+type batchJob struct {
+	idx int
+	req Request
+}
+
+// batchHandler serves POST /excel/batch, streaming one populated .xlsx per
+// employee back as a single zip. A fixed pool of GOMAXPROCS workers pulls
+// employees off a jobs channel and renders them concurrently; the handler
+// itself is the sole consumer of the results channel and writes each
+// finished entry into the zip.Writer as soon as it arrives, so generating
+// hundreds of timecards in one request never holds more than a handful of
+// rendered xlsx blobs in memory at once.
+func batchHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodOptions {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "use POST", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var batch BatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+		http.Error(w, "bad json: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(batch.Employees) == 0 {
+		http.Error(w, "employees must not be empty", http.StatusBadRequest)
+		return
+	}
+
+	jobs := make(chan batchJob)
+	results := make(chan batchResult)
+
+	workers := runtime.GOMAXPROCS(0)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				results <- generateBatchEntry(job)
+			}
+		}()
+	}
+	go func() {
+		for i, req := range batch.Employees {
+			jobs <- batchJob{idx: i, req: req}
+		}
+		close(jobs)
+	}()
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", `attachment; filename="Timecards.zip"`)
+	w.WriteHeader(http.StatusOK)
+
+	zw := zip.NewWriter(w)
+	for res := range results {
+		if res.err != nil {
+			log.Println("batch entry skipped:", res.err)
+			continue
+		}
+		fw, err := zw.Create(res.filename)
+		if err != nil {
+			log.Println("zip entry:", err)
+			continue
+		}
+		if _, err := fw.Write(res.xlsx); err != nil {
+			log.Println("zip write:", err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		log.Println("zip close:", err)
+	}
+}
+
+func generateBatchEntry(job batchJob) batchResult {
+	req := job.req
+	tc, err := populate(req)
+	if err != nil {
+		return batchResult{err: fmt.Errorf("%s: %w", req.EmployeeName, err)}
+	}
+	f, err := buildWorkbook(tc)
+	if err != nil {
+		return batchResult{err: fmt.Errorf("%s: %w", req.EmployeeName, err)}
+	}
+	defer func() { _ = f.Close() }()
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		return batchResult{err: fmt.Errorf("%s: %w", req.EmployeeName, err)}
+	}
+	return batchResult{
+		filename: fmt.Sprintf("Timecard-%s-W%d.xlsx", safeFilenamePart(req.EmployeeName, job.idx), tc.WeekNumber),
+		xlsx:     buf.Bytes(),
+	}
+}
+
+// safeFilenamePart turns name into something safe to use as a zip entry
+// filename component: path separators and ".." are replaced with "_" so a
+// crafted EmployeeName can't escape its entry into a nested or
+// traversal-style path, and an empty (or whitespace-only) name falls back
+// to the employee's position in the batch so unnamed employees don't share
+// one entry name. Two employees with the same non-empty name still get the
+// same entry name.
+func safeFilenamePart(name string, idx int) string {
+	r := strings.NewReplacer("/", "_", "\\", "_", "..", "_")
+	name = strings.TrimSpace(r.Replace(name))
+	if name == "" {
+		return fmt.Sprintf("Employee%d", idx)
+	}
+	return name
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+type csvRenderer struct{}
+
+func (csvRenderer) ContentType() string { return "text/csv" }
+func (csvRenderer) Extension() string   { return "csv" }
+
+// Render flattens the week into one row per day plus a totals trailer,
+// since a CSV has no concept of the xlsx template's three date blocks.
+func (csvRenderer) Render(w io.Writer, tc *PopulatedTimecard) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write([]string{"Employee", "Week", "Date", "Hours", "Bucket"}); err != nil {
+		return err
+	}
+	for i, dt := range tc.Dates {
+		date := ""
+		if !dt.IsZero() {
+			date = dt.Format("2006-01-02")
+		}
+		record := []string{
+			tc.EmployeeName,
+			fmt.Sprintf("%d", tc.WeekNumber),
+			date,
+			fmt.Sprintf("%.2f", tc.Hours[i]),
+			tc.RowBucket[i],
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	if err := cw.Write([]string{"", "", "", "", ""}); err != nil {
+		return err
+	}
+	totals := []struct {
+		label string
+		value float64
+	}{
+		{"Total OC", tc.OCTotal},
+		{"Total OT", tc.OTTotal},
+		{"Total Holiday", tc.HolidayTotal},
+	}
+	for _, t := range totals {
+		record := []string{"", "", t.label, fmt.Sprintf("%.2f", t.value), ""}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// HolidayRule describes how to resolve a single recognized holiday to a
+// concrete date for a given year. Most public holidays either fall on a
+// fixed month/day, or are defined relative to a weekday (e.g. "the last
+// Monday in May"), so fn is the single extension point for both.
+type HolidayRule struct {
+	Name string
+	// Subdivisions scopes the rule to specific ISO 3166-2 subdivision
+	// codes (e.g. "SCT" for Scotland, "BY" for Bavaria). An empty slice
+	// means the holiday is observed nationwide.
+	Subdivisions []string
+	fn           func(year int) time.Time
+}
+
+func fixedDate(month time.Month, day int) func(int) time.Time {
+	return func(year int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+	}
+}
+
+// nthWeekday returns the date of the n-th occurrence of weekday in month
+// (n is 1-indexed, e.g. n=4 for "the 4th Thursday").
+func nthWeekday(month time.Month, weekday time.Weekday, n int) func(int) time.Time {
+	return func(year int) time.Time {
+		first := time.Date(year, month, 1, 0, 0, 0, 0, time.Local)
+		offset := (int(weekday) - int(first.Weekday()) + 7) % 7
+		return first.AddDate(0, 0, offset+7*(n-1))
+	}
+}
+
+// lastWeekday returns the last occurrence of weekday in month.
+func lastWeekday(month time.Month, weekday time.Weekday) func(int) time.Time {
+	return func(year int) time.Time {
+		lastOfMonth := time.Date(year, month+1, 1, 0, 0, 0, 0, time.Local).AddDate(0, 0, -1)
+		offset := (int(lastOfMonth.Weekday()) - int(weekday) + 7) % 7
+		return lastOfMonth.AddDate(0, 0, -offset)
+	}
+}
+
+// easterSunday computes the Gregorian Easter Sunday via the anonymous
+// Gregorian algorithm, which several DE/GB holidays are pinned relative to.
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.Local)
+}
+
+func goodFriday(year int) time.Time { return easterSunday(year).AddDate(0, 0, -2) }
+
+// holidayCalendar is a small, built-in public-holiday dataset covering the
+// countries this deployment has customers in today. It is intentionally
+// minimal (national/major bank holidays only) rather than a full civil
+// calendar; extend per-country as new customers require it.
+var holidayCalendar = map[string][]HolidayRule{
+	"US": {
+		{Name: "New Year's Day", fn: fixedDate(time.January, 1)},
+		{Name: "Memorial Day", fn: lastWeekday(time.May, time.Monday)},
+		{Name: "Independence Day", fn: fixedDate(time.July, 4)},
+		{Name: "Labor Day", fn: nthWeekday(time.September, time.Monday, 1)},
+		{Name: "Thanksgiving Day", fn: nthWeekday(time.November, time.Thursday, 4)},
+		{Name: "Christmas Day", fn: fixedDate(time.December, 25)},
+	},
+	"CA": {
+		{Name: "New Year's Day", fn: fixedDate(time.January, 1)},
+		{Name: "Victoria Day", fn: func(year int) time.Time {
+			d := time.Date(year, time.May, 24, 0, 0, 0, 0, time.Local)
+			offset := (int(d.Weekday()) - int(time.Monday) + 7) % 7
+			return d.AddDate(0, 0, -offset)
+		}},
+		{Name: "Canada Day", fn: fixedDate(time.July, 1)},
+		{Name: "Labour Day", fn: nthWeekday(time.September, time.Monday, 1)},
+		{Name: "Thanksgiving", fn: nthWeekday(time.October, time.Monday, 2)},
+		{Name: "Christmas Day", fn: fixedDate(time.December, 25)},
+	},
+	"DE": {
+		{Name: "New Year's Day", fn: fixedDate(time.January, 1)},
+		{Name: "Epiphany", Subdivisions: []string{"BW", "BY", "ST"}, fn: fixedDate(time.January, 6)},
+		{Name: "Good Friday", fn: goodFriday},
+		{Name: "Labour Day", fn: fixedDate(time.May, 1)},
+		{Name: "German Unity Day", fn: fixedDate(time.October, 3)},
+		{Name: "Reformation Day", Subdivisions: []string{"BB", "MV", "SN", "ST", "TH"}, fn: fixedDate(time.October, 31)},
+		{Name: "Christmas Day", fn: fixedDate(time.December, 25)},
+	},
+	"GB": {
+		{Name: "New Year's Day", fn: fixedDate(time.January, 1)},
+		{Name: "Good Friday", fn: goodFriday},
+		{Name: "Early May Bank Holiday", fn: nthWeekday(time.May, time.Monday, 1)},
+		{Name: "Spring Bank Holiday", fn: lastWeekday(time.May, time.Monday)},
+		{Name: "Summer Bank Holiday", Subdivisions: []string{"SCT"}, fn: nthWeekday(time.August, time.Monday, 1)},
+		{Name: "Summer Bank Holiday", Subdivisions: []string{"ENG", "WLS", "NIR"}, fn: lastWeekday(time.August, time.Monday)},
+		{Name: "Christmas Day", fn: fixedDate(time.December, 25)},
+	},
+}
+
+// HolidayDate is a single resolved holiday occurrence for a given year,
+// suitable for marshaling to clients that want to mark up an input grid.
+type HolidayDate struct {
+	Date         time.Time `json:"date"`
+	Name         string    `json:"name"`
+	Subdivisions []string  `json:"subdivisions,omitempty"`
+}
+
+// HolidaysForYear resolves every rule registered for country into concrete
+// dates for year, sorted in calendar order. An unrecognized country yields
+// an empty slice rather than an error, since the holiday-aware bucketing
+// is additive and should never block timecard generation.
+func HolidaysForYear(country string, year int) []HolidayDate {
+	rules := holidayCalendar[strings.ToUpper(country)]
+	out := make([]HolidayDate, 0, len(rules))
+	for _, rule := range rules {
+		out = append(out, HolidayDate{Date: rule.fn(year), Name: rule.Name, Subdivisions: rule.Subdivisions})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Date.Before(out[j].Date) })
+	return out
+}
+
+// IsHoliday reports whether d falls on a recognized holiday for country,
+// scoped to subdivision when the rule requires it. subdivision may be
+// empty, in which case only nationwide holidays match.
+func IsHoliday(country, subdivision string, d time.Time) (string, bool) {
+	for _, h := range HolidaysForYear(country, d.Year()) {
+		if h.Date.Month() != d.Month() || h.Date.Day() != d.Day() {
+			continue
+		}
+		if len(h.Subdivisions) == 0 {
+			return h.Name, true
+		}
+		for _, s := range h.Subdivisions {
+			if subdivision != "" && strings.EqualFold(s, subdivision) {
+				return h.Name, true
+			}
+		}
+	}
+	return "", false
+}
@@ -2,19 +2,22 @@ package main
 
 import (
 	"bytes"
-	"embed"
 	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
 	"time"
-
-	"github.com/xuri/excelize/v2"
 )
 
-//go:embed template.xlsx
-var templateFS embed.FS
+// store is the submission history backing /timecards; initialized in main.
+var store *Store
+
+// templates is the registry of <name>.xlsx/<name>.json layouts backing
+// Request.Template; initialized in main.
+var templates *TemplateRegistry
 
 type Row struct {
 	Date    string  `json:"date"`
@@ -30,6 +33,17 @@ type Request struct {
 	Rows         []Row   `json:"rows"`
 	TotalOC      float64 `json:"totalOC"`
 	TotalOT      float64 `json:"totalOT"`
+	// Country is the ISO 3166-1 alpha-2 code used to classify Rows against
+	// the built-in holiday calendar (see holidays.go). Empty disables
+	// holiday-aware bucketing entirely.
+	Country string `json:"country"`
+	// Subdivision is an optional ISO 3166-2 code (e.g. "SCT", "BY") used to
+	// resolve holidays that are only observed in part of Country.
+	Subdivision string `json:"subdivision"`
+	// Template selects a layout registered in the TemplateRegistry (see
+	// templates.go). Empty selects the sole registered template, which is
+	// the common case for single-tenant deployments.
+	Template string `json:"template"`
 }
 
 func parseISO(d string) (time.Time, error) {
@@ -59,108 +73,128 @@ func makeHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "bad json: "+err.Error(), http.StatusBadRequest)
 		return
 	}
-	if req.WeekNumber != 1 && req.WeekNumber != 2 {
-		req.WeekNumber = 1
-	}
-	if len(req.Rows) < 7 {
-		http.Error(w, "need at least 7 rows (Sun..Sat)", http.StatusBadRequest)
-		return
-	}
 
-	tmpl, err := templateFS.ReadFile("template.xlsx")
+	ren, err := rendererFor(r)
 	if err != nil {
-		http.Error(w, "template read: "+err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	f, err := excelize.OpenReader(bytes.NewReader(tmpl))
+
+	tc, err := populate(req)
 	if err != nil {
-		http.Error(w, "open xlsx: "+err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	defer func() { _ = f.Close() }()
 
-	type weekLayout struct {
-		sheet          string
-		empCell        string
-		mainDatesTop   string
-		otDatesTop     string
+	var buf bytes.Buffer
+	if err := ren.Render(&buf, tc); err != nil {
+		http.Error(w, "render: "+err.Error(), http.StatusInternalServerError)
+		return
 	}
 
-	layout := map[int]weekLayout{
-		1: {sheet: "Week 1", empCell: "M2", mainDatesTop: "B5", otDatesTop: "B16"},
-		2: {sheet: "Week 2", empCell: "M2", mainDatesTop: "B5", otDatesTop: "B16"},
-	}[req.WeekNumber]
-
-	if req.EmployeeName != "" {
-		if err := f.SetCellValue(layout.sheet, layout.empCell, req.EmployeeName); err != nil {
-			http.Error(w, "set employee: "+err.Error(), http.StatusInternalServerError)
+	xlsxBlob := buf.Bytes()
+	if _, alreadyXlsx := ren.(xlsxRenderer); !alreadyXlsx {
+		var xbuf bytes.Buffer
+		if err := (xlsxRenderer{}).Render(&xbuf, tc); err != nil {
+			http.Error(w, "archive render: "+err.Error(), http.StatusInternalServerError)
 			return
 		}
+		xlsxBlob = xbuf.Bytes()
+	}
+	id, err := store.Insert(req, xlsxBlob)
+	if err != nil {
+		log.Println("store insert:", err)
+	} else {
+		w.Header().Set("X-Timecard-Id", id)
 	}
 
-	dateStyle, err := f.NewStyle(&excelize.Style{
-		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center"},
-		NumFmt:    14, // short date (locale), keeps borders intact
-	})
+	w.Header().Set("Content-Type", ren.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="Timecard.%s"`, ren.Extension()))
+	w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		log.Println("write error:", err)
+	}
+}
+
+// holidaysHandler serves GET /holidays/{country}/{year}, returning the
+// built-in holiday calendar resolved for that year so the frontend can
+// pre-render markers on the input grid before a timecard is ever submitted.
+// An optional ?subdivision= filters out holidays scoped to other regions.
+func holidaysHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "use GET", http.StatusMethodNotAllowed)
+		return
+	}
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/holidays/"), "/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		http.Error(w, "expected /holidays/{country}/{year}", http.StatusBadRequest)
+		return
+	}
+	country, yearStr := parts[0], parts[1]
+	year, err := strconv.Atoi(yearStr)
 	if err != nil {
-		http.Error(w, "date style: "+err.Error(), http.StatusInternalServerError)
+		http.Error(w, "bad year: "+err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	fillDates := func(top string) error {
-		col, row, err := excelize.CellNameToCoordinates(top)
-		if err != nil {
-			return err
-		}
-		for i := 0; i < 7; i++ {
-			cell, _ := excelize.CoordinatesToCellName(col, row+i)
-			dt, err := parseISO(req.Rows[i].Date)
-			if err != nil {
+	holidays := HolidaysForYear(country, year)
+	if subdivision := r.URL.Query().Get("subdivision"); subdivision != "" {
+		filtered := make([]HolidayDate, 0, len(holidays))
+		for _, h := range holidays {
+			if len(h.Subdivisions) == 0 {
+				filtered = append(filtered, h)
 				continue
 			}
-			if err := f.SetCellValue(layout.sheet, cell, dt); err != nil {
-				return err
-			}
-			if err := f.SetCellStyle(layout.sheet, cell, cell, dateStyle); err != nil {
-				return err
+			for _, s := range h.Subdivisions {
+				if strings.EqualFold(s, subdivision) {
+					filtered = append(filtered, h)
+					break
+				}
 			}
 		}
-		return nil
+		holidays = filtered
 	}
 
-	if err := fillDates(layout.mainDatesTop); err != nil {
-		http.Error(w, "main dates: "+err.Error(), http.StatusInternalServerError)
-		return
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(holidays); err != nil {
+		log.Println("encode holidays:", err)
 	}
-	if err := fillDates(layout.otDatesTop); err != nil {
-		http.Error(w, "ot dates: "+err.Error(), http.StatusInternalServerError)
+}
+
+// templatesHandler serves GET /templates, listing every registered layout
+// schema so clients can render a matching input form dynamically instead
+// of hardcoding cell knowledge.
+func templatesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "use GET", http.StatusMethodNotAllowed)
 		return
 	}
-
-	if t0, err := parseISO(req.Rows[0].Date); err == nil {
-		// Set the big "Sun Date Start" box to the Sunday of that week
-		sunday := t0.AddDate(0, 0, -int((int(t0.Weekday())+7-0)%7))
-		_ = f.SetCellValue(layout.sheet, "B4", sunday)
-		_ = f.SetCellStyle(layout.sheet, "B4", "B4", dateStyle)
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(templates.List()); err != nil {
+		log.Println("encode templates:", err)
 	}
+}
 
-	buf, err := f.WriteToBuffer()
+func main() {
+	s, err := newStore("")
 	if err != nil {
-		http.Error(w, "write xlsx: "+err.Error(), http.StatusInternalServerError)
-		return
+		log.Fatal("open store: ", err)
 	}
+	store = s
 
-	w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
-	w.Header().Set("Content-Disposition", `attachment; filename="Timecard.xlsx"`)
-	w.Header().Set("Content-Length", fmt.Sprintf("%d", buf.Len()))
-	w.WriteHeader(http.StatusOK)
-	if _, err := w.Write(buf.Bytes()); err != nil {
-		log.Println("write error:", err)
+	reg, err := loadTemplateRegistry(os.Getenv("TEMPLATES_DIR"))
+	if err != nil {
+		log.Fatal("load templates: ", err)
 	}
-}
+	templates = reg
 
-func main() {
 	http.HandleFunc("/excel", makeHandler)
+	http.HandleFunc("/excel/batch", batchHandler)
+	http.HandleFunc("/holidays/", holidaysHandler)
+	http.HandleFunc("/timecards", timecardsIndexHandler)
+	http.HandleFunc("/timecards/", timecardItemHandler)
+	http.HandleFunc("/templates", templatesHandler)
 	http.HandleFunc("/health", func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte("ok"))
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+type pdfRenderer struct{}
+
+func (pdfRenderer) ContentType() string { return "application/pdf" }
+func (pdfRenderer) Extension() string   { return "pdf" }
+
+// Render draws the same three blocks (regular, overtime, holiday) the xlsx
+// template uses, top to bottom in the same order as the B5/B16/B27 blocks,
+// so a PDF printout and the spreadsheet read identically.
+func (pdfRenderer) Render(w io.Writer, tc *PopulatedTimecard) error {
+	pdf := gofpdf.New("P", "mm", "Letter", "")
+	pdf.AddPage()
+
+	pdf.SetFont("Helvetica", "B", 14)
+	pdf.CellFormat(0, 10, "Timecard - "+tc.Sheet, "", 1, "C", false, 0, "")
+
+	pdf.SetFont("Helvetica", "", 11)
+	pdf.CellFormat(0, 8, "Employee: "+tc.EmployeeName, "", 1, "L", false, 0, "")
+	if !tc.Sunday.IsZero() {
+		pdf.CellFormat(0, 8, "Week of: "+tc.Sunday.Format("2006-01-02"), "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(4)
+
+	writeBlock := func(title, wantBucket string) {
+		pdf.SetFont("Helvetica", "B", 11)
+		pdf.CellFormat(0, 7, title, "", 1, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 10)
+		for i, dt := range tc.Dates {
+			if dt.IsZero() || tc.RowBucket[i] != wantBucket {
+				continue
+			}
+			pdf.CellFormat(60, 6, dt.Format("Mon 2006-01-02"), "1", 0, "L", false, 0, "")
+			pdf.CellFormat(30, 6, fmt.Sprintf("%.2f", tc.Hours[i]), "1", 1, "R", false, 0, "")
+		}
+		pdf.Ln(2)
+	}
+	writeBlock("Regular Hours", "Regular")
+	writeBlock("Overtime Hours", "Overtime")
+	writeBlock("Holiday Hours", "Holiday")
+
+	pdf.SetFont("Helvetica", "B", 11)
+	totals := []struct {
+		label string
+		value float64
+	}{
+		{"Total OC", tc.OCTotal},
+		{"Total OT", tc.OTTotal},
+		{"Total Holiday", tc.HolidayTotal},
+	}
+	for _, t := range totals {
+		pdf.CellFormat(60, 7, t.label, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(30, 7, fmt.Sprintf("%.2f", t.value), "1", 1, "R", false, 0, "")
+	}
+
+	return pdf.Output(w)
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// PopulatedTimecard is the renderer-agnostic result of resolving a Request
+// against the week layout: dates parsed, holiday hours classified, totals
+// carried over. Every Renderer builds its output from this struct alone,
+// so template-cell logic (which dates go where, what counts as holiday)
+// lives here once instead of being duplicated per output format.
+type PopulatedTimecard struct {
+	TemplateName string
+	Schema       TemplateSchema
+	// templateXLSX is the resolved template's xlsx bytes, cached here so
+	// buildWorkbook doesn't need a second by-name registry lookup.
+	templateXLSX []byte
+	Sheet        string
+	WeekNumber   int
+	EmployeeName string
+
+	// Dates holds one entry for each of the first seven Rows (Sun..Sat);
+	// rows past the seventh are ignored. A zero time.Time marks a
+	// row whose date could not be parsed and should be left blank.
+	Dates []time.Time
+	// Hours and RowBucket are parallel to Dates: RowBucket is one of
+	// "Holiday", "Overtime" or "Regular".
+	Hours     []float64
+	RowBucket []string
+
+	Sunday time.Time
+
+	OCTotal      float64
+	OTTotal      float64
+	HolidayTotal float64
+}
+
+// populate resolves req into a PopulatedTimecard. It does not touch
+// excelize, CSV, or PDF APIs — those concerns live in the Renderer
+// implementations.
+func populate(req Request) (*PopulatedTimecard, error) {
+	if req.WeekNumber != 1 && req.WeekNumber != 2 {
+		req.WeekNumber = 1
+	}
+	if len(req.Rows) < 7 {
+		return nil, fmt.Errorf("need at least 7 rows (Sun..Sat)")
+	}
+
+	tmpl, err := templates.Get(req.Template)
+	if err != nil {
+		return nil, err
+	}
+	sheet, err := tmpl.schema.sheetForWeek(req.WeekNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	tc := &PopulatedTimecard{
+		TemplateName: tmpl.schema.Name,
+		Schema:       tmpl.schema,
+		templateXLSX: tmpl.xlsx,
+		Sheet:        sheet,
+		WeekNumber:   req.WeekNumber,
+		EmployeeName: req.EmployeeName,
+		// OCTotal/OTTotal start from the client-supplied totals rather than
+		// being re-derived from Row.Type, which most clients never set.
+		// Hours reclassified as Holiday below are subtracted back out so a
+		// holiday day's hours still land in HolidayTotal only.
+		OCTotal: req.TotalOC,
+		OTTotal: req.TotalOT,
+	}
+
+	for i := 0; i < 7; i++ {
+		row := req.Rows[i]
+		dt, err := parseISO(row.Date)
+		if err != nil {
+			tc.Dates = append(tc.Dates, time.Time{})
+			tc.Hours = append(tc.Hours, row.Hours)
+			tc.RowBucket = append(tc.RowBucket, rowBucket(row))
+			continue
+		}
+		tc.Dates = append(tc.Dates, dt)
+		tc.Hours = append(tc.Hours, row.Hours)
+
+		bucket := rowBucket(row)
+		if req.Country != "" {
+			if _, ok := IsHoliday(req.Country, req.Subdivision, dt); ok {
+				reclassifyAsHoliday(tc, bucket, row.Hours)
+				bucket = "Holiday"
+			}
+		}
+		tc.RowBucket = append(tc.RowBucket, bucket)
+	}
+
+	if t0 := tc.Dates[0]; !t0.IsZero() {
+		tc.Sunday = t0.AddDate(0, 0, -int(t0.Weekday()))
+	}
+
+	return tc, nil
+}
+
+// rowBucket classifies a row as Overtime or Regular absent a holiday
+// override; holiday classification is applied by the caller since it
+// needs the request's country/subdivision.
+func rowBucket(row Row) string {
+	if row.Type == "OT" || row.Type == "overtime" {
+		return "Overtime"
+	}
+	return "Regular"
+}
+
+// reclassifyAsHoliday moves hours out of whichever of OCTotal/OTTotal
+// fromBucket fed and into HolidayTotal, keeping the three totals
+// mutually exclusive even though OCTotal/OTTotal started from the
+// client-supplied req.TotalOC/req.TotalOT rather than a per-row sum.
+func reclassifyAsHoliday(tc *PopulatedTimecard, fromBucket string, hours float64) {
+	if fromBucket == "Overtime" {
+		tc.OTTotal -= hours
+	} else {
+		tc.OCTotal -= hours
+	}
+	tc.HolidayTotal += hours
+}
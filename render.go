@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Renderer turns a PopulatedTimecard into bytes in one output format.
+// Implementations must not depend on the original Request or on each
+// other; everything they need comes from the PopulatedTimecard.
+type Renderer interface {
+	ContentType() string
+	Extension() string
+	Render(w io.Writer, tc *PopulatedTimecard) error
+}
+
+// ods is intentionally not registered here: excelize has no ODS writer, so
+// there is no format to back an "ods" Renderer yet. Add one only once a
+// real ODS encoder is wired in — don't register a format that always
+// errors.
+var renderers = map[string]Renderer{
+	"xlsx": xlsxRenderer{},
+	"csv":  csvRenderer{},
+	"pdf":  pdfRenderer{},
+}
+
+// acceptRenderers maps MIME types clients may send via Accept to the
+// format key they select, for callers that prefer content negotiation
+// over an explicit ?format= query parameter.
+var acceptRenderers = map[string]string{
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet": "xlsx",
+	"text/csv":        "csv",
+	"application/pdf": "pdf",
+}
+
+// rendererFor picks a Renderer from the request's ?format= query
+// parameter, falling back to the Accept header, and defaulting to xlsx
+// to preserve the endpoint's original behavior for existing clients.
+func rendererFor(r *http.Request) (Renderer, error) {
+	format := strings.ToLower(r.URL.Query().Get("format"))
+	if format == "" {
+		format = formatFromAccept(r.Header.Get("Accept"))
+	}
+	if format == "" {
+		format = "xlsx"
+	}
+	ren, ok := renderers[format]
+	if !ok {
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+	return ren, nil
+}
+
+// formatFromAccept picks the first media type in an Accept header (e.g.
+// "text/csv, */*;q=0.8") that maps to a registered renderer, honoring the
+// header's listed preference order rather than its q-values. A bare "*/*"
+// entry never matches on its own, so it can't accidentally force a format.
+func formatFromAccept(accept string) string {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType := strings.TrimSpace(part)
+		if i := strings.Index(mediaType, ";"); i >= 0 {
+			mediaType = strings.TrimSpace(mediaType[:i])
+		}
+		if mediaType == "" || mediaType == "*/*" {
+			continue
+		}
+		if format, ok := acceptRenderers[mediaType]; ok {
+			return format
+		}
+	}
+	return ""
+}
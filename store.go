@@ -0,0 +1,160 @@
+package main
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// Store persists every accepted Request alongside its canonical xlsx blob,
+// so a timecard can be re-downloaded or audited without the client
+// resending the original submission.
+type Store struct {
+	db *sql.DB
+}
+
+// TimecardRecord is the JSON-facing view of a stored submission; it never
+// carries the xlsx blob itself, which is fetched separately by ID.
+type TimecardRecord struct {
+	ID           string    `json:"id"`
+	EmployeeName string    `json:"employeeName"`
+	WeekNumber   int       `json:"weekNumber"`
+	SubmittedAt  time.Time `json:"submittedAt"`
+}
+
+// newStore opens (and, if needed, creates) the sqlite database at path,
+// defaulting to TIMECARD_DB_PATH or "timecards.db" in the working
+// directory when path is empty.
+func newStore(path string) (*Store, error) {
+	if path == "" {
+		path = os.Getenv("TIMECARD_DB_PATH")
+	}
+	if path == "" {
+		path = "timecards.db"
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite at %s: %w", path, err)
+	}
+	const schema = `
+CREATE TABLE IF NOT EXISTS timecards (
+	id            TEXT PRIMARY KEY,
+	employee_name TEXT NOT NULL,
+	week_number   INTEGER NOT NULL,
+	submitted_at  DATETIME NOT NULL,
+	xlsx          BLOB NOT NULL
+);`
+	if _, err := db.Exec(schema); err != nil {
+		return nil, fmt.Errorf("create schema: %w", err)
+	}
+	return &Store{db: db}, nil
+}
+
+func newTimecardID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Insert records req and its rendered xlsx blob, returning the generated ID.
+func (s *Store) Insert(req Request, xlsx []byte) (string, error) {
+	id, err := newTimecardID()
+	if err != nil {
+		return "", err
+	}
+	_, err = s.db.Exec(
+		`INSERT INTO timecards (id, employee_name, week_number, submitted_at, xlsx) VALUES (?, ?, ?, ?, ?)`,
+		id, req.EmployeeName, req.WeekNumber, time.Now().UTC(), xlsx,
+	)
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// TimecardFilter narrows List to a subset of stored submissions; zero
+// values are treated as "no filter" for that field.
+type TimecardFilter struct {
+	Employee string
+	Week     int
+	From     time.Time
+	To       time.Time
+}
+
+// List returns stored submissions matching filter, most recent first.
+func (s *Store) List(filter TimecardFilter) ([]TimecardRecord, error) {
+	query := `SELECT id, employee_name, week_number, submitted_at FROM timecards WHERE 1=1`
+	var args []any
+
+	if filter.Employee != "" {
+		query += ` AND employee_name = ?`
+		args = append(args, filter.Employee)
+	}
+	if filter.Week != 0 {
+		query += ` AND week_number = ?`
+		args = append(args, filter.Week)
+	}
+	if !filter.From.IsZero() {
+		query += ` AND submitted_at >= ?`
+		args = append(args, filter.From)
+	}
+	if !filter.To.IsZero() {
+		query += ` AND submitted_at <= ?`
+		args = append(args, filter.To)
+	}
+	query += ` ORDER BY submitted_at DESC`
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var out []TimecardRecord
+	for rows.Next() {
+		var rec TimecardRecord
+		if err := rows.Scan(&rec.ID, &rec.EmployeeName, &rec.WeekNumber, &rec.SubmittedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, rec)
+	}
+	return out, rows.Err()
+}
+
+// Get returns the stored xlsx blob and metadata for id.
+func (s *Store) Get(id string) (*TimecardRecord, []byte, error) {
+	var rec TimecardRecord
+	var xlsx []byte
+	err := s.db.QueryRow(
+		`SELECT id, employee_name, week_number, submitted_at, xlsx FROM timecards WHERE id = ?`, id,
+	).Scan(&rec.ID, &rec.EmployeeName, &rec.WeekNumber, &rec.SubmittedAt, &xlsx)
+	if err == sql.ErrNoRows {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return &rec, xlsx, nil
+}
+
+// Delete removes the stored submission for id, reporting whether a row
+// was actually removed.
+func (s *Store) Delete(id string) (bool, error) {
+	res, err := s.db.Exec(`DELETE FROM timecards WHERE id = ?`, id)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
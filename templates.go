@@ -0,0 +1,155 @@
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+//go:embed template.xlsx template.json
+var defaultTemplateFS embed.FS
+
+// TemplateSchema is the <name>.json sidecar for a <name>.xlsx template: it
+// declares every cell this server needs to populate that workbook, so a
+// new client layout never requires a rebuild.
+type TemplateSchema struct {
+	Name string `json:"name"`
+	// Sheets maps week number (as a string key, since JSON object keys are
+	// always strings) to the sheet name holding that week's grid.
+	Sheets           map[string]string `json:"sheets"`
+	EmployeeCell     string            `json:"employeeCell"`
+	MainDatesTop     string            `json:"mainDatesTop"`
+	OTDatesTop       string            `json:"otDatesTop"`
+	HolidayDatesTop  string            `json:"holidayDatesTop"`
+	SundayCell       string            `json:"sundayCell"`
+	OCTotalCell      string            `json:"ocTotalCell"`
+	OTTotalCell      string            `json:"otTotalCell"`
+	HolidayTotalCell string            `json:"holidayTotalCell"`
+	// Labels holds any extra label->cell mappings a tenant's layout needs
+	// beyond the fields above (e.g. a department code box). buildWorkbook
+	// writes each key as static text into its mapped cell.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+func (s TemplateSchema) sheetForWeek(week int) (string, error) {
+	sheet, ok := s.Sheets[strconv.Itoa(week)]
+	if !ok {
+		return "", fmt.Errorf("template %q has no sheet for week %d", s.Name, week)
+	}
+	return sheet, nil
+}
+
+// registeredTemplate pairs a schema with the xlsx bytes it describes.
+type registeredTemplate struct {
+	schema TemplateSchema
+	xlsx   []byte
+}
+
+// TemplateRegistry holds every <name>.xlsx/<name>.json pair discovered
+// under TEMPLATES_DIR at startup, so different clients or departments can
+// each use their own timecard layout without a binary rebuild.
+type TemplateRegistry struct {
+	byName map[string]*registeredTemplate
+}
+
+// loadTemplateRegistry reads every <name>.json/<name>.xlsx pair from dir.
+// An empty dir path falls back to the single embedded default template, so
+// the server still runs out of the box with zero configuration; a directory
+// holding no .json files is an error.
+func loadTemplateRegistry(dir string) (*TemplateRegistry, error) {
+	reg := &TemplateRegistry{byName: map[string]*registeredTemplate{}}
+
+	if dir == "" {
+		t, err := loadTemplate(defaultTemplateFS.ReadFile, "template")
+		if err != nil {
+			return nil, err
+		}
+		reg.byName[t.schema.Name] = t
+		return reg, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read templates dir %s: %w", dir, err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), ".json")
+		t, err := loadTemplate(func(f string) ([]byte, error) {
+			return os.ReadFile(filepath.Join(dir, f))
+		}, name)
+		if err != nil {
+			return nil, err
+		}
+		reg.byName[t.schema.Name] = t
+	}
+	if len(reg.byName) == 0 {
+		return nil, fmt.Errorf("no templates found in %s", dir)
+	}
+	return reg, nil
+}
+
+func loadTemplate(read func(string) ([]byte, error), name string) (*registeredTemplate, error) {
+	schemaBytes, err := read(name + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("read %s.json: %w", name, err)
+	}
+	var schema TemplateSchema
+	if err := json.Unmarshal(schemaBytes, &schema); err != nil {
+		return nil, fmt.Errorf("parse %s.json: %w", name, err)
+	}
+	if schema.Name == "" {
+		schema.Name = name
+	}
+
+	xlsx, err := read(name + ".xlsx")
+	if err != nil {
+		return nil, fmt.Errorf("read %s.xlsx: %w", name, err)
+	}
+
+	return &registeredTemplate{schema: schema, xlsx: xlsx}, nil
+}
+
+// Get returns the named template, or the sole registered template when
+// name is empty (the common case for single-tenant deployments). With
+// more than one template registered, name must be given explicitly — there
+// is no "default" to guess at for a multi-tenant deployment.
+func (r *TemplateRegistry) Get(name string) (*registeredTemplate, error) {
+	if name == "" {
+		if len(r.byName) != 1 {
+			return nil, fmt.Errorf("template required: registry has %d templates, specify one of %v", len(r.byName), r.names())
+		}
+		for _, t := range r.byName {
+			return t, nil
+		}
+	}
+	t, ok := r.byName[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown template %q, available: %v", name, r.names())
+	}
+	return t, nil
+}
+
+func (r *TemplateRegistry) names() []string {
+	out := make([]string, 0, len(r.byName))
+	for name := range r.byName {
+		out = append(out, name)
+	}
+	return out
+}
+
+// List returns every registered schema, for the GET /templates admin
+// endpoint so clients can render matching input forms dynamically.
+func (r *TemplateRegistry) List() []TemplateSchema {
+	out := make([]TemplateSchema, 0, len(r.byName))
+	for _, t := range r.byName {
+		out = append(out, t.schema)
+	}
+	return out
+}
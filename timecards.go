@@ -0,0 +1,98 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// timecardsIndexHandler serves GET /timecards?employee=&week=&from=&to=,
+// returning the stored submissions matching those filters as a JSON list.
+func timecardsIndexHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "use GET", http.StatusMethodNotAllowed)
+		return
+	}
+
+	q := r.URL.Query()
+	filter := TimecardFilter{Employee: q.Get("employee")}
+	if weekStr := q.Get("week"); weekStr != "" {
+		week, err := strconv.Atoi(weekStr)
+		if err != nil {
+			http.Error(w, "bad week: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		filter.Week = week
+	}
+	if fromStr := q.Get("from"); fromStr != "" {
+		from, err := parseISO(fromStr)
+		if err != nil {
+			http.Error(w, "bad from: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		filter.From = from
+	}
+	if toStr := q.Get("to"); toStr != "" {
+		to, err := parseISO(toStr)
+		if err != nil {
+			http.Error(w, "bad to: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		filter.To = to
+	}
+
+	records, err := store.List(filter)
+	if err != nil {
+		http.Error(w, "list: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(records); err != nil {
+		log.Println("encode timecards:", err)
+	}
+}
+
+// timecardItemHandler serves GET /timecards/{id}.xlsx (re-download the
+// original file) and DELETE /timecards/{id}.
+func timecardItemHandler(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/timecards/"), ".xlsx")
+	if id == "" {
+		http.Error(w, "missing id", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		rec, xlsx, err := store.Get(id)
+		if err != nil {
+			http.Error(w, "get: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if rec == nil {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+		w.Header().Set("Content-Disposition", `attachment; filename="Timecard.xlsx"`)
+		w.Header().Set("Content-Length", strconv.Itoa(len(xlsx)))
+		if _, err := w.Write(xlsx); err != nil {
+			log.Println("write error:", err)
+		}
+	case http.MethodDelete:
+		ok, err := store.Delete(id)
+		if err != nil {
+			http.Error(w, "delete: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if !ok {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "use GET or DELETE", http.StatusMethodNotAllowed)
+	}
+}
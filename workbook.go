@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// buildWorkbook opens tc's registered template and fills it in.
+func buildWorkbook(tc *PopulatedTimecard) (*excelize.File, error) {
+	f, err := excelize.OpenReader(bytes.NewReader(tc.templateXLSX))
+	if err != nil {
+		return nil, err
+	}
+
+	schema := tc.Schema
+
+	if tc.EmployeeName != "" {
+		if err := f.SetCellValue(tc.Sheet, schema.EmployeeCell, tc.EmployeeName); err != nil {
+			return nil, err
+		}
+	}
+
+	dateStyle, err := f.NewStyle(&excelize.Style{
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center"},
+		NumFmt:    14, // short date (locale), keeps borders intact
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// fillDates writes only the rows whose RowBucket matches wantBucket,
+	// so each of the three blocks holds just its own kind of day (regular,
+	// overtime, or holiday) instead of all seven dates in every block.
+	fillDates := func(top, wantBucket string) error {
+		col, row, err := excelize.CellNameToCoordinates(top)
+		if err != nil {
+			return err
+		}
+		for i, dt := range tc.Dates {
+			if dt.IsZero() || tc.RowBucket[i] != wantBucket {
+				continue
+			}
+			cell, _ := excelize.CoordinatesToCellName(col, row+i)
+			if err := f.SetCellValue(tc.Sheet, cell, dt); err != nil {
+				return err
+			}
+			if err := f.SetCellStyle(tc.Sheet, cell, cell, dateStyle); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := fillDates(schema.MainDatesTop, "Regular"); err != nil {
+		return nil, err
+	}
+	if err := fillDates(schema.OTDatesTop, "Overtime"); err != nil {
+		return nil, err
+	}
+	if err := fillDates(schema.HolidayDatesTop, "Holiday"); err != nil {
+		return nil, err
+	}
+
+	if err := f.SetCellValue(tc.Sheet, schema.OCTotalCell, tc.OCTotal); err != nil {
+		return nil, err
+	}
+	if err := f.SetCellValue(tc.Sheet, schema.OTTotalCell, tc.OTTotal); err != nil {
+		return nil, err
+	}
+	if err := f.SetCellValue(tc.Sheet, schema.HolidayTotalCell, tc.HolidayTotal); err != nil {
+		return nil, err
+	}
+
+	for label, cell := range schema.Labels {
+		if err := f.SetCellValue(tc.Sheet, cell, label); err != nil {
+			return nil, err
+		}
+	}
+
+	if !tc.Sunday.IsZero() {
+		// Set the big "Sun Date Start" box to the Sunday of that week
+		if err := f.SetCellValue(tc.Sheet, schema.SundayCell, tc.Sunday); err != nil {
+			return nil, err
+		}
+		if err := f.SetCellStyle(tc.Sheet, schema.SundayCell, schema.SundayCell, dateStyle); err != nil {
+			return nil, err
+		}
+	}
+
+	return f, nil
+}
+
+type xlsxRenderer struct{}
+
+func (xlsxRenderer) ContentType() string {
+	return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+}
+func (xlsxRenderer) Extension() string { return "xlsx" }
+
+func (xlsxRenderer) Render(w io.Writer, tc *PopulatedTimecard) error {
+	f, err := buildWorkbook(tc)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(buf.Bytes())
+	return err
+}